@@ -0,0 +1,16 @@
+package frame
+
+import "time"
+
+// SystemClock is default Clock, backed by real time.
+// Can be embedded into custom Clock, to override only some methods
+type SystemClock struct {
+}
+
+func (c SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c SystemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
@@ -1,7 +1,17 @@
 package frame
 
+import "time"
+
 type (
 	logger interface {
 		Error(err error)
 	}
+
+	// Clock is time source of executor and tasks scheduler.
+	// Can be replaced with WithClock, for example to run
+	// game loop with fake time in tests
+	Clock interface {
+		Now() time.Time
+		Sleep(d time.Duration)
+	}
 )
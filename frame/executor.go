@@ -3,38 +3,54 @@ package frame
 import (
 	"context"
 	"math"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-glx/frames/frame/internal/schedule"
 )
 
 const defaultTPS = 60
+const defaultMaxTicksPerCycle = 5
 
 type (
 	Executor struct {
 		tasks            []*Task
 		logger           logger
+		clock            Clock
 		frameErrBehavior ErrBehavior
 		targetTPS        int
+		targetFPS        int
+		maxTicks         int
+		drawWhilePaused  bool
 		statsCollector   fnCollect
+		statsWindow      *statsWindow
 
 		// state
-		interrupted bool
-		scheduler   *schedule.Scheduler
-		stats       Stats
+		paused    int32 // atomic, 1 when paused
+		scheduler *schedule.Scheduler
+		scheduled map[*Task]*schedule.Task
+		stats     Stats
+
+		// guard scheduled tasks, that can be
+		// added/removed from another goroutines
+		mux sync.Mutex
 	}
 
 	fnCollect = func(stats Stats)
 	fnTick    = func(tickStats TickStats) error
-	fnDraw    = func() error
+	fnDraw    = func(frameStats FrameStats) error
 )
 
 func NewExecutor(initializers ...ExecutorInitializer) *Executor {
 	e := &Executor{
 		tasks:            []*Task{},
 		logger:           &fallbackLogger{},
+		clock:            SystemClock{},
 		frameErrBehavior: ErrBehaviorExit,
 		targetTPS:        defaultTPS,
+		maxTicks:         defaultMaxTicksPerCycle,
+		scheduled:        map[*Task]*schedule.Task{},
 	}
 
 	for _, init := range initializers {
@@ -42,63 +58,216 @@ func NewExecutor(initializers ...ExecutorInitializer) *Executor {
 	}
 
 	e.scheduler = schedule.NewScheduler(
-		schedule.NewPrioritize(func() time.Time {
-			return time.Now()
-		}),
-		transformTasks(e.tasks)...,
+		schedule.NewPrioritize(e.clock.Now),
 	)
+	e.scheduler.OnRunOnceRemoved(e.forgetTask)
+
+	for _, task := range e.tasks {
+		e.AddTask(task)
+	}
 
 	return e
 }
 
+// AddTask will register new task in executor, task will be
+// scheduled from next cycle. Adding already registered task do nothing.
+// Safe to call from another goroutine, while Execute is running
+func (e *Executor) AddTask(task *Task) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	// one-shot task can be already auto removed from scheduler,
+	// but not forgotten yet, in this case it should be added again
+	if innerTask, exist := e.scheduled[task]; exist && e.scheduler.Has(innerTask) {
+		return
+	}
+
+	innerTask := transformTaskToInternal(task)
+	e.scheduled[task] = innerTask
+	e.scheduler.Add(innerTask)
+}
+
+// RemoveTask will unregister task from executor, and return true,
+// when this task was registered before.
+// Safe to call from another goroutine, while Execute is running
+func (e *Executor) RemoveTask(task *Task) bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	innerTask, exist := e.scheduled[task]
+	if !exist {
+		return false
+	}
+
+	delete(e.scheduled, task)
+	return e.scheduler.Remove(innerTask)
+}
+
+// forgetTask will remove one-shot task from registered
+// tasks, after scheduler auto removed it
+func (e *Executor) forgetTask(innerTask *schedule.Task) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	for task, registered := range e.scheduled {
+		if registered == innerTask {
+			delete(e.scheduled, task)
+			return
+		}
+	}
+}
+
+// Pause will stop ticks and tasks processing, until Resume is called.
+// Frames also will not be drawn, except when WithDrawWhilePaused is set.
+// Safe to call from another goroutine, while Execute is running
+func (e *Executor) Pause() {
+	atomic.StoreInt32(&e.paused, 1)
+}
+
+// Resume will continue ticks and tasks processing after Pause.
+// Safe to call from another goroutine, while Execute is running
+func (e *Executor) Resume() {
+	atomic.StoreInt32(&e.paused, 0)
+}
+
+// TaskStats return runtime stats of all registered tasks,
+// in tasks registration order.
+// Safe to call from another goroutine, while Execute is running
+func (e *Executor) TaskStats() []TaskStats {
+	innerStats := e.scheduler.Stats()
+	stats := make([]TaskStats, 0, len(innerStats))
+
+	for _, innerStat := range innerStats {
+		stats = append(stats, transformTaskStatsFromInternal(innerStat))
+	}
+
+	return stats
+}
+
+// Execute will run game loop, until ctx is not canceled.
+// drawFn can be nil, for headless mode (dedicated game servers, etc..),
+// in this mode frame step is skipped at all, and all cycle
+// budget will be used by ticks and tasks.
 func (e *Executor) Execute(ctx context.Context, updateFn fnTick, drawFn fnDraw) error {
-	// handle cancel
-	go func() {
-		<-ctx.Done()
-		e.interrupted = true
-	}()
+	headless := drawFn == nil
 
 	// initialize loop state
 	e.stats.CycleID = 0
 	e.stats.TargetTPS = e.targetTPS
 	e.stats.Rate = time.Second / time.Duration(e.stats.TargetTPS)
-	e.stats.Game.Start = time.Now()
+	e.stats.Game.Start = e.clock.Now()
+	e.stats.TargetFPS = e.stats.TargetTPS
 	e.stats.CurrentTPS = e.stats.TargetTPS
-	e.stats.CurrentFPS = e.stats.TargetTPS
+
+	// cycle rate is equal to tick rate, until frames
+	// is not decoupled from ticks with custom TargetFPS.
+	// When decoupled, cycle will run at the fastest of both rates
+	cycleRate := e.stats.Rate
+	frameRate := time.Duration(0)
+	decoupled := e.targetFPS > 0 && !headless
+
+	if headless {
+		e.stats.TargetFPS = 0
+	}
+
+	if decoupled {
+		e.stats.TargetFPS = e.targetFPS
+		frameRate = time.Second / time.Duration(e.targetFPS)
+
+		if frameRate < cycleRate {
+			cycleRate = frameRate
+		}
+	}
+
+	e.stats.CurrentFPS = e.stats.TargetFPS
 
 	// private state
-	lastSyncAt := time.Now().Add(-e.stats.Rate)
+	lastSyncAt := e.clock.Now().Add(-cycleRate)
+	initialTickLeftover := time.Duration(0)
+	initialFrameLeftover := time.Duration(0)
+
+	if decoupled {
+		// first cycle always has tick and frame
+		initialTickLeftover = e.stats.Rate - cycleRate
+		initialFrameLeftover = frameRate - cycleRate
+	}
+
+	tickLeftover := initialTickLeftover
+	frameLeftover := initialFrameLeftover
+	lastFrameAt := lastSyncAt
+	syncStartAt := e.stats.Game.Start
+	syncCycleID := uint64(1)
 	throttleCorrection := time.Duration(0)
-	resetCountersAt := time.Now().Add(time.Second)
+	wasPaused := false
+	resetCountersAt := e.clock.Now().Add(time.Second)
 	currentTPS := 0
 	currentFPS := 0
 
-	for !e.interrupted {
+	for {
+		// handle cancel
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
 		// Start
 		// -------------------------
 		e.stats.CycleID++
-		e.stats.Cycle.Start = time.Now()
+		e.stats.Cycle.Start = e.clock.Now()
+		e.stats.Paused = atomic.LoadInt32(&e.paused) == 1
+
+		if wasPaused && !e.stats.Paused {
+			// resumed, so reset time baseline, otherwise loop will
+			// try to catch up all time spent in pause
+			lastSyncAt = e.stats.Cycle.Start.Add(-cycleRate)
+			syncStartAt = e.stats.Cycle.Start
+			syncCycleID = e.stats.CycleID
+			tickLeftover = initialTickLeftover
+			frameLeftover = initialFrameLeftover
+		}
+		wasPaused = e.stats.Paused
 
 		deltaTime := e.stats.Cycle.Start.Sub(lastSyncAt)
 		lastSyncAt = lastSyncAt.Add(deltaTime)
 
 		// calculate throttle correction
 		// this will snap loop cycles to Rate intervals
-		idealStartAt := e.stats.Game.Start.Add(
-			time.Duration(e.stats.CycleID-1) * e.stats.Rate,
+		idealStartAt := syncStartAt.Add(
+			time.Duration(e.stats.CycleID-syncCycleID) * cycleRate,
 		)
 
 		diffFromIdeal := e.stats.Cycle.Start.Sub(idealStartAt).Microseconds()
-		diffFromIdeal = int64(math.Mod(float64(diffFromIdeal), float64(e.stats.Rate.Microseconds())))
+		diffFromIdeal = int64(math.Mod(float64(diffFromIdeal), float64(cycleRate.Microseconds())))
 		throttleCorrection = time.Duration(diffFromIdeal) * time.Microsecond
 
 		// Tick
 		// -------------------------
-		e.stats.Tick.Start = time.Now()
+		e.stats.Tick.Start = e.clock.Now()
+		e.stats.DroppedTicks = 0
+		ticksCount := 0
 		updateDelta := e.stats.Rate + deltaTime
 		requiredUpdate := true
+		tickDeltaTime := deltaTime
+		tickDueAt := e.stats.Rate + 1 // more than Rate
+
+		if decoupled {
+			// cycles can be shorter than Rate, so ticks should
+			// accumulate time between cycles and run only when due.
+			// Tick is due as soon as full Rate is accumulated
+			updateDelta = tickLeftover + deltaTime
+			requiredUpdate = false
+			tickDeltaTime = e.stats.Rate
+			tickDueAt = e.stats.Rate
+		}
 
-		for updateDelta > e.stats.Rate {
+		if e.stats.Paused {
+			// game time is frozen
+			updateDelta = tickLeftover
+			requiredUpdate = false
+		}
+
+		for updateDelta >= tickDueAt {
 			if requiredUpdate {
 				// this will guarantee one update call every cycle
 				// if deltaTime less that Rate,
@@ -108,10 +277,22 @@ func (e *Executor) Execute(ctx context.Context, updateFn fnTick, drawFn fnDraw)
 				updateDelta -= e.stats.Rate
 			}
 
+			if ticksCount >= e.maxTicks {
+				// cycle is too far behind, catch up all missed ticks
+				// will take even more time, so next cycle will lag again.
+				// All not processed time is discarded
+				e.stats.DroppedTicks = int(updateDelta / e.stats.Rate)
+				updateDelta %= e.stats.Rate
+				break
+			}
+
+			ticksCount++
 			currentTPS++
-			err := updateFn(TickStats{
-				CycleID:   e.stats.CycleID,
-				DeltaTime: deltaTime.Seconds(),
+			err := schedule.SafeCall(func() error {
+				return updateFn(TickStats{
+					CycleID:   e.stats.CycleID,
+					DeltaTime: tickDeltaTime.Seconds(),
+				})
 			})
 			if err != nil {
 				if nextErr := e.handleError(err); nextErr != nil {
@@ -121,29 +302,91 @@ func (e *Executor) Execute(ctx context.Context, updateFn fnTick, drawFn fnDraw)
 
 			updateDelta -= e.stats.Rate
 		}
-		e.stats.Tick.Duration = time.Since(e.stats.Tick.Start)
+		e.stats.Tick.Duration = e.clock.Now().Sub(e.stats.Tick.Start)
+
+		// alpha is not consumed by ticks time, used for
+		// interpolation between previous and current game state.
+		// Without decoupling, tick always just ran before frame
+		alpha := float64(0)
+
+		if decoupled {
+			tickLeftover = updateDelta
+			alpha = math.Max(0, math.Min(1, float64(tickLeftover)/float64(e.stats.Rate)))
+		}
 
 		// Frame
 		// -------------------------
-		e.stats.Frame.Start = time.Now()
-		currentFPS++
-		err := drawFn()
-		if err != nil {
-			if nextErr := e.handleError(err); nextErr != nil {
-				return nextErr
+		e.stats.Frame.Start = e.clock.Now()
+		e.stats.Frame.Duration = 0
+		requiredDraw := true
+
+		if decoupled {
+			frameLeftover += deltaTime
+			requiredDraw = frameLeftover >= frameRate
+		}
+
+		if e.stats.Paused && !e.drawWhilePaused {
+			requiredDraw = false
+		}
+
+		if headless {
+			requiredDraw = false
+		}
+
+		if requiredDraw {
+			if decoupled {
+				frameLeftover -= frameRate
+
+				if frameLeftover >= frameRate {
+					// lagging, missed frames will not be drawn,
+					// so not need to keep its time
+					frameLeftover = 0
+				}
 			}
+
+			currentFPS++
+			frameDeltaTime := e.stats.Frame.Start.Sub(lastFrameAt)
+			lastFrameAt = e.stats.Frame.Start
+
+			err := schedule.SafeCall(func() error {
+				return drawFn(FrameStats{
+					CycleID:   e.stats.CycleID,
+					Alpha:     alpha,
+					DeltaTime: frameDeltaTime.Seconds(),
+				})
+			})
+			if err != nil {
+				if nextErr := e.handleError(err); nextErr != nil {
+					return nextErr
+				}
+			}
+			e.stats.Frame.Duration = e.clock.Now().Sub(e.stats.Frame.Start)
 		}
-		e.stats.Frame.Duration = time.Since(e.stats.Frame.Start)
 
 		// Tasks
 		// -------------------------
 		totalSpend := e.stats.Tick.Duration + e.stats.Frame.Duration
-		freeTime := e.stats.Rate - totalSpend
-		e.stats.PossibleFPS = int(time.Second / totalSpend)
+		freeTime := cycleRate - totalSpend
+
+		if totalSpend > 0 && requiredDraw {
+			// cycles without frame not represent frame cost
+			e.stats.PossibleFPS = int(time.Second / totalSpend)
+		}
 
-		e.stats.Tasks.Start = time.Now()
-		e.scheduler.Execute(freeTime)
-		e.stats.Tasks.Duration = time.Since(e.stats.Tasks.Start)
+		e.stats.Tasks.Start = e.clock.Now()
+		e.stats.Tasks.Duration = 0
+
+		// pause can be requested in this cycle (from tick or frame),
+		// so tasks should check actual state, not cycle start state
+		if atomic.LoadInt32(&e.paused) == 0 {
+			err := e.scheduler.Execute(freeTime)
+			e.stats.Tasks.Duration = e.clock.Now().Sub(e.stats.Tasks.Start)
+			if err != nil {
+				if nextErr := e.handleError(err); nextErr != nil {
+					return nextErr
+				}
+			}
+		}
 
 		// Throttle
 		// -------------------------
@@ -152,7 +395,7 @@ func (e *Executor) Execute(ctx context.Context, updateFn fnTick, drawFn fnDraw)
 			e.stats.Frame.Duration +
 			e.stats.Tasks.Duration
 
-		e.stats.ThrottleTime = e.stats.Rate - timeTaken
+		e.stats.ThrottleTime = cycleRate - timeTaken
 
 		if throttleCorrection > 0 {
 			e.stats.ThrottleTime -= throttleCorrection
@@ -162,27 +405,40 @@ func (e *Executor) Execute(ctx context.Context, updateFn fnTick, drawFn fnDraw)
 			e.stats.ThrottleTime = 0
 		}
 
-		time.Sleep(e.stats.ThrottleTime)
+		e.clock.Sleep(e.stats.ThrottleTime)
 
 		// End
 		// -------------------------
-		e.stats.Cycle.Duration = time.Since(e.stats.Cycle.Start)
-		e.stats.Game.Duration = time.Since(e.stats.Game.Start)
+		e.stats.Cycle.Duration = e.clock.Now().Sub(e.stats.Cycle.Start)
+		e.stats.Game.Duration = e.clock.Now().Sub(e.stats.Game.Start)
 
-		if time.Now().After(resetCountersAt) {
-			resetCountersAt = time.Now().Add(time.Second)
+		if !e.clock.Now().Before(resetCountersAt) {
+			resetCountersAt = e.clock.Now().Add(time.Second)
 			e.stats.CurrentTPS = currentTPS
 			e.stats.CurrentFPS = currentFPS
 			currentTPS = 0
 			currentFPS = 0
 		}
 
+		if e.statsWindow != nil {
+			e.statsWindow.push(e.stats, ticksCount, requiredDraw)
+		}
+
 		if e.statsCollector != nil {
 			e.statsCollector(e.stats)
 		}
 	}
+}
 
-	return nil
+// StatsSnapshot return aggregated stats of all cycles
+// in last window, configured with WithStatsWindow.
+// Safe to call from another goroutine, while Execute is running
+func (e *Executor) StatsSnapshot() StatsSnapshot {
+	if e.statsWindow == nil {
+		return StatsSnapshot{}
+	}
+
+	return e.statsWindow.snapshot()
 }
 
 func (e *Executor) handleError(err error) error {
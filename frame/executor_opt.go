@@ -2,6 +2,7 @@ package frame
 
 import (
 	"fmt"
+	"time"
 )
 
 type (
@@ -26,6 +27,18 @@ func WithStatsCollector(collector fnCollect) ExecutorInitializer {
 	}
 }
 
+// WithStatsWindow will aggregate stats of all cycles in last
+// window duration, aggregated stats available in Executor.StatsSnapshot
+func WithStatsWindow(window time.Duration) ExecutorInitializer {
+	return func(e *Executor) {
+		if window <= 0 {
+			panic(fmt.Errorf("StatsWindow should be greater than zero"))
+		}
+
+		e.statsWindow = newStatsWindow(window)
+	}
+}
+
 func WithTargetTPS(targetTPS int) ExecutorInitializer {
 	return func(e *Executor) {
 		if targetTPS <= 0 {
@@ -36,8 +49,50 @@ func WithTargetTPS(targetTPS int) ExecutorInitializer {
 	}
 }
 
+// WithTargetFPS will decouple frames from ticks, so drawFn
+// will be called at own targetFPS rate, independent of TargetTPS.
+// Zero value (default) will draw exactly one frame per cycle
+func WithTargetFPS(targetFPS int) ExecutorInitializer {
+	return func(e *Executor) {
+		if targetFPS < 0 {
+			panic(fmt.Errorf("TargetFPS should be greater or equal to zero"))
+		}
+
+		e.targetFPS = targetFPS
+	}
+}
+
+// WithMaxTicksPerCycle limit how many ticks can be executed in one cycle,
+// when cycle is lagging and try to catch up missed ticks.
+// Time of all ticks above this limit will be discarded (see Stats.DroppedTicks)
+func WithMaxTicksPerCycle(maxTicks int) ExecutorInitializer {
+	return func(e *Executor) {
+		if maxTicks <= 0 {
+			panic(fmt.Errorf("MaxTicksPerCycle should be greater than zero"))
+		}
+
+		e.maxTicks = maxTicks
+	}
+}
+
+// WithDrawWhilePaused will keep drawing frames, while executor
+// is paused (ticks and tasks anyway will not be executed)
+func WithDrawWhilePaused(draw bool) ExecutorInitializer {
+	return func(e *Executor) {
+		e.drawWhilePaused = draw
+	}
+}
+
 func WithLogger(logger logger) ExecutorInitializer {
 	return func(e *Executor) {
 		e.logger = logger
 	}
 }
+
+// WithClock will replace system time source, used by executor
+// and tasks scheduler. Useful for deterministic tests
+func WithClock(clock Clock) ExecutorInitializer {
+	return func(e *Executor) {
+		e.clock = clock
+	}
+}
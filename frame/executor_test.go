@@ -81,10 +81,568 @@ func TestExecutor_Execute(t *testing.T) {
 	err := executor.Execute(ctx, func(_ TickStats) error {
 		time.Sleep(testExampleLatencyTick)
 		return nil
-	}, func() error {
+	}, func(_ FrameStats) error {
 		time.Sleep(testExampleLatencyFrame)
 		return nil
 	})
 
 	assert.NoError(t, err)
 }
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2000, 01, 01, 12, 0, 0, 0, time.UTC)}
+}
+
+// testCycles will run executor with fake clock
+// for exact count of cycles, and collect stats of all them
+type testCycles struct {
+	clock    *testClock
+	executor *Executor
+	onCycle  func(stats Stats)
+	stats    []Stats
+	cancel   context.CancelFunc
+	cycles   uint64
+}
+
+func newTestCycles(clock *testClock, cycles uint64, opts ...ExecutorInitializer) *testCycles {
+	c := &testCycles{
+		clock:  clock,
+		stats:  make([]Stats, 0, cycles),
+		cycles: cycles,
+	}
+
+	opts = append(opts,
+		WithClock(clock),
+		WithStatsCollector(func(stats Stats) {
+			c.stats = append(c.stats, stats)
+
+			if c.onCycle != nil {
+				c.onCycle(stats)
+			}
+
+			if stats.CycleID == c.cycles {
+				c.cancel()
+			}
+		}),
+	)
+
+	c.executor = NewExecutor(opts...)
+	return c
+}
+
+func (c *testCycles) execute(updateFn fnTick, drawFn fnDraw) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.cancel = cancel
+	return c.executor.Execute(ctx, updateFn, drawFn)
+}
+
+func TestExecutor_ExecuteDecoupledTicksWithClock(t *testing.T) {
+	tests := []struct {
+		name            string
+		tps             int
+		fps             int
+		wantTickCycles  []uint64
+		wantFrameCycles []uint64
+		wantPossibleFPS map[int]bool
+	}{
+		{
+			name:            "fps > tps",
+			tps:             10,
+			fps:             40,
+			wantTickCycles:  []uint64{1, 5, 9},
+			wantFrameCycles: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			wantPossibleFPS: map[int]bool{
+				200:  true, // tick + frame
+				1000: true, // frame only
+			},
+		},
+		{
+			name:            "fps < tps",
+			tps:             40,
+			fps:             10,
+			wantTickCycles:  []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			wantFrameCycles: []uint64{1, 5, 9},
+			wantPossibleFPS: map[int]bool{
+				200: true, // tick + frame, cycles with tick only keep previous value
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			run := newTestCycles(clock, 12,
+				WithTargetTPS(tt.tps),
+				WithTargetFPS(tt.fps),
+			)
+
+			tickCycles := make([]uint64, 0)
+			frameCycles := make([]uint64, 0)
+
+			err := run.execute(func(st TickStats) error {
+				tickCycles = append(tickCycles, st.CycleID)
+				clock.Sleep(time.Millisecond * 4)
+				return nil
+			}, func(st FrameStats) error {
+				frameCycles = append(frameCycles, st.CycleID)
+				clock.Sleep(time.Millisecond * 1)
+				return nil
+			})
+
+			possibleFPS := map[int]bool{}
+			for _, stats := range run.stats {
+				possibleFPS[stats.PossibleFPS] = true
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTickCycles, tickCycles)
+			assert.Equal(t, tt.wantFrameCycles, frameCycles)
+			assert.Equal(t, tt.wantPossibleFPS, possibleFPS, "possible fps calculated only on cycles with frame")
+		})
+	}
+}
+
+func TestExecutor_ExecuteFrameAlpha(t *testing.T) {
+	run := newTestCycles(newTestClock(), 9,
+		WithTargetTPS(10),
+		WithTargetFPS(40),
+	)
+
+	alphas := make([]float64, 0)
+
+	err := run.execute(func(_ TickStats) error {
+		return nil
+	}, func(st FrameStats) error {
+		alphas = append(alphas, st.Alpha)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0, 0.25, 0.5, 0.75, 0, 0.25, 0.5, 0.75, 0}, alphas)
+}
+
+func TestExecutor_ExecuteCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cycles := 0
+	executor := NewExecutor(
+		WithTargetTPS(testExampleTicksRate),
+		WithStatsCollector(func(_ Stats) {
+			cycles++
+			if cycles == 3 {
+				cancel()
+			}
+		}),
+	)
+
+	err := executor.Execute(ctx, func(_ TickStats) error {
+		return nil
+	}, func(_ FrameStats) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, cycles)
+}
+
+func TestExecutor_ExecuteMaxTicksPerCycle(t *testing.T) {
+	clock := newTestClock()
+	run := newTestCycles(clock, 10,
+		WithTargetTPS(10),
+		WithMaxTicksPerCycle(2),
+	)
+
+	ticksInCycle := map[uint64]int{}
+
+	err := run.execute(func(st TickStats) error {
+		ticksInCycle[st.CycleID]++
+		clock.Sleep(time.Millisecond * 20)
+
+		if st.CycleID == 5 {
+			// emulate lag
+			clock.Sleep(time.Millisecond * 500)
+		}
+
+		return nil
+	}, func(_ FrameStats) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, run.stats, 10)
+
+	for _, stats := range run.stats {
+		if stats.CycleID == 6 {
+			// lagged cycle 5 took 520ms, so 5 ticks is due,
+			// but only 2 executed, and 3 is dropped
+			assert.Equal(t, 2, ticksInCycle[stats.CycleID])
+			assert.Equal(t, 3, stats.DroppedTicks)
+			continue
+		}
+
+		assert.Equal(t, 1, ticksInCycle[stats.CycleID], "cycle %d", stats.CycleID)
+		assert.Equal(t, 0, stats.DroppedTicks, "cycle %d", stats.CycleID)
+	}
+}
+
+type testErrLogger struct {
+	errors []error
+}
+
+func (l *testErrLogger) Error(err error) {
+	l.errors = append(l.errors, err)
+}
+
+func TestExecutor_ExecutePanicRecover(t *testing.T) {
+	const (
+		siteTick = "tick"
+		siteDraw = "draw"
+		siteTask = "task"
+	)
+
+	tests := []struct {
+		name     string
+		site     string
+		behavior ErrBehavior
+		wantExit bool
+	}{
+		{name: "tick exit", site: siteTick, behavior: ErrBehaviorExit, wantExit: true},
+		{name: "tick log", site: siteTick, behavior: ErrBehaviorLog, wantExit: false},
+		{name: "draw exit", site: siteDraw, behavior: ErrBehaviorExit, wantExit: true},
+		{name: "draw log", site: siteDraw, behavior: ErrBehaviorLog, wantExit: false},
+		{name: "task exit", site: siteTask, behavior: ErrBehaviorExit, wantExit: true},
+		{name: "task log", site: siteTask, behavior: ErrBehaviorLog, wantExit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+			defer cancel()
+
+			panicAt := func(site string) {
+				if site == tt.site {
+					panic(fmt.Sprintf("%s is broken", site))
+				}
+			}
+
+			logger := &testErrLogger{}
+			executor := NewExecutor(
+				WithTargetTPS(testExampleTicksRate),
+				WithFrameErrorHandleBehavior(tt.behavior),
+				WithLogger(logger),
+				WithTask(NewTask(func() {
+					panicAt(siteTask)
+				})),
+			)
+
+			err := executor.Execute(ctx, func(_ TickStats) error {
+				panicAt(siteTick)
+				return nil
+			}, func(_ FrameStats) error {
+				panicAt(siteDraw)
+				return nil
+			})
+
+			if tt.wantExit {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), fmt.Sprintf("%s is broken", tt.site))
+				assert.Equal(t, uint64(1), executor.stats.CycleID)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, logger.errors)
+			assert.Greater(t, executor.stats.CycleID, uint64(1))
+		})
+	}
+}
+
+func TestExecutor_AddRemoveTask(t *testing.T) {
+	clock := newTestClock()
+	run := newTestCycles(clock, 12,
+		WithTargetTPS(10),
+	)
+
+	runCycles := make([]uint64, 0)
+	task := NewTask(func() {
+		runCycles = append(runCycles, run.executor.stats.CycleID)
+		clock.Sleep(time.Millisecond * 5)
+	},
+		WithRunAtMostOnceIn(time.Millisecond*10),
+	)
+
+	run.onCycle = func(stats Stats) {
+		switch stats.CycleID {
+		case 3:
+			run.executor.AddTask(task)
+		case 7:
+			assert.True(t, run.executor.RemoveTask(task))
+			assert.False(t, run.executor.RemoveTask(task))
+		}
+	}
+
+	err := run.execute(func(_ TickStats) error {
+		clock.Sleep(time.Millisecond * 20)
+		return nil
+	}, func(_ FrameStats) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{4, 5, 6, 7}, runCycles, "task should run only between add and remove")
+}
+
+func TestExecutor_PauseResume(t *testing.T) {
+	clock := newTestClock()
+	run := newTestCycles(clock, 15,
+		WithTargetTPS(10),
+		WithDrawWhilePaused(true),
+	)
+
+	ticksInCycle := map[uint64]int{}
+	pausedCycles := 0
+	pausedFrames := 0
+
+	run.onCycle = func(stats Stats) {
+		if !stats.Paused {
+			return
+		}
+
+		pausedCycles++
+		if pausedCycles == 3 {
+			run.executor.Resume()
+		}
+	}
+
+	err := run.execute(func(st TickStats) error {
+		assert.False(t, run.executor.stats.Paused, "ticks should not run while paused")
+
+		ticksInCycle[st.CycleID]++
+		clock.Sleep(time.Millisecond * 20)
+
+		if st.CycleID == 5 {
+			run.executor.Pause()
+		}
+
+		return nil
+	}, func(_ FrameStats) error {
+		if run.executor.stats.Paused {
+			// emulate heavy pause menu, longer than cycle Rate
+			pausedFrames++
+			clock.Sleep(time.Millisecond * 250)
+			return nil
+		}
+
+		clock.Sleep(time.Millisecond * 10)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pausedCycles)
+	assert.Equal(t, 3, pausedFrames, "frames should be drawn while paused")
+
+	// cycles 6, 7, 8 is paused
+	for cycleID := uint64(6); cycleID <= 8; cycleID++ {
+		assert.True(t, run.stats[cycleID-1].Paused)
+		assert.Equal(t, 0, ticksInCycle[cycleID])
+	}
+
+	// first cycle after resume, should not try to catch up
+	// pause time, and should be snapped to Rate from new baseline
+	resumed := run.stats[8]
+	assert.False(t, resumed.Paused)
+	assert.Equal(t, 1, ticksInCycle[resumed.CycleID])
+	assert.Equal(t, 0, resumed.DroppedTicks)
+	assert.Equal(t, time.Millisecond*70, resumed.ThrottleTime)
+}
+
+func TestExecutor_PauseFromTick(t *testing.T) {
+	clock := newTestClock()
+	runCycles := make([]uint64, 0)
+
+	run := newTestCycles(clock, 8,
+		WithTargetTPS(10),
+	)
+	run.executor.AddTask(NewTask(func() {
+		runCycles = append(runCycles, run.executor.stats.CycleID)
+	},
+		WithRunAtMostOnceIn(time.Millisecond*10),
+	))
+
+	run.onCycle = func(stats Stats) {
+		if stats.CycleID == 5 {
+			run.executor.Resume()
+		}
+	}
+
+	err := run.execute(func(st TickStats) error {
+		clock.Sleep(time.Millisecond * 20)
+
+		if st.CycleID == 3 {
+			run.executor.Pause()
+		}
+
+		return nil
+	}, func(_ FrameStats) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, run.stats[2].Paused, "pause applied from next cycle")
+	assert.True(t, run.stats[3].Paused)
+	assert.True(t, run.stats[4].Paused)
+	assert.Equal(t, []uint64{1, 2, 6, 7, 8}, runCycles, "tasks should not run in cycle, where pause requested")
+}
+
+func TestExecutor_ExecuteWithClock(t *testing.T) {
+	clock := newTestClock()
+	run := newTestCycles(clock, 25,
+		WithTargetTPS(10),
+	)
+
+	err := run.execute(func(_ TickStats) error {
+		clock.Sleep(time.Millisecond * 20)
+		return nil
+	}, func(_ FrameStats) error {
+		clock.Sleep(time.Millisecond * 10)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, run.stats, 25)
+
+	for ind, stats := range run.stats {
+		assert.Equal(t, uint64(ind+1), stats.CycleID)
+		assert.Equal(t, time.Millisecond*100, stats.Rate)
+		assert.Equal(t, time.Millisecond*20, stats.Tick.Duration)
+		assert.Equal(t, time.Millisecond*10, stats.Frame.Duration)
+		assert.Equal(t, time.Millisecond*70, stats.ThrottleTime)
+		assert.Equal(t, time.Millisecond*100, stats.Cycle.Duration)
+		assert.Equal(t, 10, stats.CurrentTPS)
+		assert.Equal(t, 10, stats.CurrentFPS)
+		assert.Equal(t, 33, stats.PossibleFPS)
+	}
+}
+
+func TestExecutor_ExecuteHeadless(t *testing.T) {
+	clock := newTestClock()
+	run := newTestCycles(clock, 25,
+		WithTargetTPS(10),
+		WithTargetFPS(60), // ignored in headless mode
+	)
+
+	ticks := 0
+	err := run.execute(func(_ TickStats) error {
+		ticks++
+		clock.Sleep(time.Millisecond * 20)
+		return nil
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, run.stats, 25)
+	assert.Equal(t, 25, ticks)
+
+	for _, stats := range run.stats {
+		assert.Equal(t, 0, stats.TargetFPS)
+		assert.Equal(t, 0, stats.CurrentFPS)
+		assert.Equal(t, 0, stats.PossibleFPS)
+		assert.Equal(t, time.Duration(0), stats.Frame.Duration)
+		assert.Equal(t, time.Millisecond*80, stats.ThrottleTime)
+		assert.Equal(t, time.Millisecond*100, stats.Cycle.Duration)
+		assert.Equal(t, 10, stats.CurrentTPS)
+	}
+}
+
+func TestExecutor_ExecuteRunOnceTask(t *testing.T) {
+	clock := newTestClock()
+	runs := 0
+	task := NewTask(func() {
+		runs++
+	},
+		WithRunOnce(),
+	)
+
+	run := newTestCycles(clock, 25,
+		WithTargetTPS(10),
+		WithTask(task),
+	)
+
+	err := run.execute(func(_ TickStats) error {
+		clock.Sleep(time.Millisecond * 20)
+		return nil
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runs)
+	assert.Empty(t, run.executor.scheduled, "one-shot task should be forgotten after run")
+	assert.False(t, run.executor.RemoveTask(task), "one-shot task should be removed after run")
+}
+
+func TestExecutor_TaskStats(t *testing.T) {
+	clock := newTestClock()
+	run := newTestCycles(clock, 25,
+		WithTargetTPS(10),
+		WithTask(NewTask(func() {
+			clock.Sleep(time.Millisecond * 5)
+		},
+			WithName("autosave"),
+			WithRunAtMostOnceIn(time.Millisecond*500),
+		)),
+		WithTask(NewTask(func() {},
+			WithName("idle"),
+			WithRunAtMostOnceIn(time.Hour),
+		)),
+	)
+
+	// stats can be read from another goroutine
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = run.executor.TaskStats()
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	err := run.execute(func(_ TickStats) error {
+		clock.Sleep(time.Millisecond * 20)
+		return nil
+	}, nil)
+	close(stop)
+	<-done
+
+	assert.NoError(t, err)
+
+	stats := run.executor.TaskStats()
+	assert.Len(t, stats, 2)
+
+	assert.Equal(t, "autosave", stats[0].Name)
+	assert.Equal(t, uint64(5), stats[0].RunsCount) // cycles: 1, 6, 11, 16, 21
+	assert.Equal(t, time.Millisecond*5, stats[0].AvgDuration)
+	assert.False(t, stats[0].LastRunAt.IsZero())
+
+	assert.Equal(t, "idle", stats[1].Name)
+	assert.Equal(t, uint64(1), stats[1].RunsCount)
+	assert.Equal(t, float32(-1), stats[1].LastPriority, "idle task runs too often")
+}
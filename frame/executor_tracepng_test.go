@@ -245,7 +245,7 @@ func TestTraceExecutor(t *testing.T) {
 		}
 
 		currentFrameID := 0
-		fnDraw := func() error {
+		fnDraw := func(_ FrameStats) error {
 			currentFrameID++
 			measures = append(measures, testMeasureFunction(testTraceBlockFrame, func() {
 				time.Sleep(variant.latencyFrame)
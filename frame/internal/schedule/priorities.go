@@ -46,9 +46,18 @@ func NewPrioritize(obtainer timeObtainer) *Prioritize {
 //   1 - the highest priority
 //   2 - task overdue, should be executed right now, without capacity check
 func (p *Prioritize) calculateTaskPriority(task *Task) float32 {
-	sinceLast := p.getTime().Sub(task.lastRunAt)
+	lastRunAt := task.lastRunAt
+	waitingFirstRun := lastRunAt.IsZero() && task.runOnce
 
-	if sinceLast < task.runAtMostOnceIn {
+	if waitingFirstRun {
+		// one-shot task is eligible right after registration,
+		// but not overdue, so it should wait for free time
+		lastRunAt = task.registeredAt
+	}
+
+	sinceLast := p.getTime().Sub(lastRunAt)
+
+	if sinceLast < task.runAtMostOnceIn && !waitingFirstRun {
 		// reject task that runs too often
 		return runPriorityNotNeed
 	}
@@ -75,8 +84,8 @@ func (p *Prioritize) calculateTaskPriority(task *Task) float32 {
 	// 0.75 | 0.52 | 0.75 | 1.00
 	// 1.00 | 1.00 | 1.00 | 1.00
 
-	maxOverdueAt := task.lastRunAt.Add(task.runAtLeastOnceIn)
-	currentPos := float64(p.getTime().UnixMicro()-task.lastRunAt.UnixMicro()) / float64(maxOverdueAt.UnixMicro()-task.lastRunAt.UnixMicro())
+	maxOverdueAt := lastRunAt.Add(task.runAtLeastOnceIn)
+	currentPos := float64(p.getTime().UnixMicro()-lastRunAt.UnixMicro()) / float64(maxOverdueAt.UnixMicro()-lastRunAt.UnixMicro())
 
 	return float32(currentPos) * priorityAsMultiplier[task.priority]
 }
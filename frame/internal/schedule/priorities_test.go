@@ -95,6 +95,30 @@ func Test_calculateTaskPriority(t *testing.T) {
 			},
 			want: 1.125,
 		},
+		{
+			name:        "one-shot eligible right after registration",
+			currentTime: currentTime,
+			task: &Task{
+				priority:         PriorityNormal,
+				runAtLeastOnceIn: time.Second,
+				runAtMostOnceIn:  time.Second,
+				runOnce:          true,
+				registeredAt:     currentTime,
+			},
+			want: 0,
+		},
+		{
+			name:        "one-shot overdue since registration",
+			currentTime: currentTime,
+			task: &Task{
+				priority:         PriorityNormal,
+				runAtLeastOnceIn: time.Second,
+				runAtMostOnceIn:  time.Second,
+				runOnce:          true,
+				registeredAt:     currentTime.Add(-(time.Second * 2)),
+			},
+			want: runPriorityCritical,
+		},
 	}
 
 	for _, tt := range tests {
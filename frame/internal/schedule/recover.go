@@ -0,0 +1,28 @@
+package schedule
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// SafeCall will execute fn, and convert any panic inside it
+// into error, so caller (game loop or scheduler) will not be unwound.
+// When panic value is error, it will be wrapped and can be
+// checked with errors.Is / errors.As
+func SafeCall(fn func() error) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		if panicErr, ok := r.(error); ok {
+			err = fmt.Errorf("panic: %w\n%s", panicErr, debug.Stack())
+			return
+		}
+
+		err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+	}()
+
+	return fn()
+}
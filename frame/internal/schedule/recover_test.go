@@ -0,0 +1,30 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeCall(t *testing.T) {
+	errBroken := errors.New("broken")
+
+	t.Run("no panic", func(t *testing.T) {
+		assert.NoError(t, SafeCall(func() error { return nil }))
+		assert.ErrorIs(t, SafeCall(func() error { return errBroken }), errBroken)
+	})
+
+	t.Run("panic with value", func(t *testing.T) {
+		err := SafeCall(func() error { panic("broken value") })
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "panic: broken value")
+	})
+
+	t.Run("panic with error", func(t *testing.T) {
+		err := SafeCall(func() error { panic(errBroken) })
+
+		assert.ErrorIs(t, err, errBroken)
+	})
+}
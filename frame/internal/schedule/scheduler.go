@@ -2,31 +2,54 @@ package schedule
 
 import (
 	"sort"
+	"sync"
 	"time"
 )
 
 type Scheduler struct {
 	prioritize *Prioritize
 	tasks      []*Task
+	queue      []*Task // tasks in run order for current Execute call
+	nextIndex  uint64  // registration index of next added task
+	onRunOnce  func(task *Task)
+
+	// guard tasks list, that can be
+	// modified from another goroutines
+	mux sync.Mutex
 }
 
 func NewScheduler(prioritize *Prioritize, tasks ...*Task) *Scheduler {
-	return &Scheduler{
+	s := &Scheduler{
 		prioritize: prioritize,
-		tasks:      tasks,
+		tasks:      make([]*Task, 0, len(tasks)),
+	}
+
+	for _, task := range tasks {
+		s.Add(task)
 	}
+
+	return s
 }
 
-func (s *Scheduler) Execute(capacity time.Duration) {
+// Execute will run tasks, that fit into capacity time.
+// When some task panics, execution of current cycle is stopped
+// and the panic returned as error
+func (s *Scheduler) Execute(capacity time.Duration) error {
+	s.mux.Lock()
 	for _, lazyTask := range s.tasks {
 		lazyTask.currentPriority = s.prioritize.calculateTaskPriority(lazyTask)
 	}
 
-	sort.Slice(s.tasks, func(i, j int) bool {
-		return s.tasks[i].currentPriority >= s.tasks[j].currentPriority
+	sort.SliceStable(s.tasks, func(i, j int) bool {
+		return s.runsBefore(s.tasks[i], s.tasks[j])
 	})
 
-	for _, task := range s.tasks {
+	// tasks list can be changed while tasks executing,
+	// so iterate over own copy of it
+	s.queue = append(s.queue[:0], s.tasks...)
+	s.mux.Unlock()
+
+	for _, task := range s.queue {
 		if task.currentPriority == runPriorityNotNeed {
 			// not need run this task right now
 			continue
@@ -34,7 +57,12 @@ func (s *Scheduler) Execute(capacity time.Duration) {
 
 		if task.currentPriority == runPriorityCritical {
 			// should be executed right now
-			capacity -= s.run(task)
+			duration, err := s.run(task)
+			if err != nil {
+				return err
+			}
+
+			capacity -= duration
 			continue
 		}
 
@@ -45,8 +73,8 @@ func (s *Scheduler) Execute(capacity time.Duration) {
 		if task.avgDuration <= 0 {
 			// don`t known duration yet, possible > capacity
 			// so run only this at current frame
-			s.run(task)
-			break
+			_, err := s.run(task)
+			return err
 		}
 
 		if task.avgDuration > capacity {
@@ -54,19 +82,165 @@ func (s *Scheduler) Execute(capacity time.Duration) {
 			continue
 		}
 
-		capacity -= s.run(task)
+		duration, err := s.run(task)
+		if err != nil {
+			return err
+		}
+
+		capacity -= duration
+	}
+
+	return nil
+}
+
+// OnRunOnceRemoved set handler, that will be called right after
+// one-shot task is auto removed from scheduler after successful run
+func (s *Scheduler) OnRunOnceRemoved(handler func(task *Task)) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.onRunOnce = handler
+}
+
+// Add will register new task, it will be
+// scheduled from next Execute call.
+// Safe to call from another goroutine
+func (s *Scheduler) Add(task *Task) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.indexOf(task) != -1 {
+		return
+	}
+
+	task.index = s.nextIndex
+	task.registeredAt = s.prioritize.getTime()
+	s.nextIndex++
+
+	s.tasks = append(s.tasks, task)
+}
+
+// Remove will unregister task, and return true if it was registered.
+// Removed task will not be executed anymore, even in current Execute call,
+// but task that already running right now, will not be interrupted.
+// Safe to call from another goroutine
+func (s *Scheduler) Remove(task *Task) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.remove(task)
+}
+
+// Stats return snapshot of all registered tasks runtime stats,
+// in tasks registration order.
+// Safe to call from another goroutine
+func (s *Scheduler) Stats() []TaskStats {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	stats := make([]TaskStats, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		stats = append(stats, TaskStats{
+			Name:         task.name,
+			RunsCount:    task.runsCount,
+			AvgDuration:  task.avgDuration,
+			LastRunAt:    task.lastRunAt,
+			LastPriority: task.currentPriority,
+			index:        task.index,
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].index < stats[j].index
+	})
+
+	return stats
+}
+
+// Has return true, when task is registered in scheduler.
+// Safe to call from another goroutine
+func (s *Scheduler) Has(task *Task) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.indexOf(task) != -1
+}
+
+func (s *Scheduler) remove(task *Task) bool {
+	ind := s.indexOf(task)
+	if ind == -1 {
+		return false
+	}
+
+	s.tasks = append(s.tasks[:ind], s.tasks[ind+1:]...)
+	return true
+}
+
+// runsBefore define strict tasks order: by priority,
+// on ties by oldest last run, and then by registration order
+func (s *Scheduler) runsBefore(a, b *Task) bool {
+	if a.currentPriority != b.currentPriority {
+		return a.currentPriority > b.currentPriority
+	}
+
+	if !a.lastRunAt.Equal(b.lastRunAt) {
+		return a.lastRunAt.Before(b.lastRunAt)
+	}
+
+	return a.index < b.index
+}
+
+func (s *Scheduler) indexOf(task *Task) int {
+	for ind, registered := range s.tasks {
+		if registered == task {
+			return ind
+		}
 	}
+
+	return -1
 }
 
 // Run function and return it duration
-func (s *Scheduler) run(task *Task) time.Duration {
-	task.lastRunAt = time.Now()
-	task.taskFn()
-	duration := time.Since(task.lastRunAt)
+func (s *Scheduler) run(task *Task) (time.Duration, error) {
+	s.mux.Lock()
+	registered := s.indexOf(task) != -1
+	s.mux.Unlock()
+
+	if !registered {
+		// removed after run queue was prepared
+		return 0, nil
+	}
+
+	startAt := s.prioritize.getTime()
+	err := SafeCall(func() error {
+		task.taskFn()
+		return nil
+	})
+	duration := s.prioritize.getTime().Sub(startAt)
+
+	// task stats can be read from another goroutine
+	s.mux.Lock()
+	task.lastRunAt = startAt
+
+	if err != nil {
+		// not pollute task stats with broken run
+		s.mux.Unlock()
+		return duration, err
+	}
 
 	task.avgDuration = ((task.avgDuration * time.Duration(task.runsCount)) + duration) /
 		(time.Duration(task.runsCount) + 1)
 
 	task.runsCount++
-	return duration
+
+	removed := task.runOnce && s.remove(task)
+	handler := s.onRunOnce
+	s.mux.Unlock()
+
+	if removed && handler != nil {
+		// called without lock, so handler can use scheduler
+		handler(task)
+	}
+
+	return duration, nil
 }
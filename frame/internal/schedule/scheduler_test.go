@@ -137,11 +137,12 @@ func Test_scheduler_Execute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			currentTime = testMakeTime(30, 0)
 			actualResults := make([]string, 0)
 
 			s := &Scheduler{
 				prioritize: NewPrioritize(getTime),
-				tasks:      testPrepareTasksToRun(tt.tasks, &actualResults),
+				tasks:      testPrepareTasksToRun(tt.tasks, &actualResults, &currentTime),
 			}
 			s.Execute(tt.capacity)
 
@@ -150,13 +151,14 @@ func Test_scheduler_Execute(t *testing.T) {
 	}
 }
 
-func testPrepareTasksToRun(tasks map[string]*Task, resultBuffer *[]string) []*Task {
+func testPrepareTasksToRun(tasks map[string]*Task, resultBuffer *[]string, now *time.Time) []*Task {
 	prepared := make([]*Task, 0, len(tasks))
 
 	for name, task := range tasks {
 		name, task := name, task
 		task.taskFn = func() {
-			time.Sleep(task.avgDuration)
+			// emulate task work time
+			*now = now.Add(task.avgDuration)
 			*resultBuffer = append(*resultBuffer, name)
 		}
 
@@ -165,3 +167,161 @@ func testPrepareTasksToRun(tasks map[string]*Task, resultBuffer *[]string) []*Ta
 
 	return prepared
 }
+
+func Test_scheduler_ExecutePanic(t *testing.T) {
+	currentTime := testMakeTime(30, 0)
+	getTime := func() time.Time {
+		return currentTime
+	}
+
+	task := testCreateTask(currentTime.Add(-(time.Second * 10)))
+	task.taskFn = func() {
+		panic("broken task")
+	}
+
+	s := NewScheduler(NewPrioritize(getTime), task)
+	err := s.Execute(time.Millisecond * 100)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "broken task")
+	assert.Equal(t, uint64(10), task.runsCount, "panic run should not be counted")
+	assert.Equal(t, time.Millisecond*10, task.avgDuration, "panic run should not affect avg duration")
+}
+
+func Test_scheduler_AddRemove(t *testing.T) {
+	currentTime := testMakeTime(30, 0)
+	getTime := func() time.Time {
+		return currentTime
+	}
+
+	actualResults := make([]string, 0)
+	tasks := map[string]*Task{
+		"apple": testCreateTask(currentTime.Add(-(time.Second))),
+	}
+
+	s := NewScheduler(NewPrioritize(getTime))
+	for _, task := range testPrepareTasksToRun(tasks, &actualResults, &currentTime) {
+		s.Add(task)
+		s.Add(task) // duplicate is ignored
+	}
+
+	assert.NoError(t, s.Execute(time.Millisecond*100))
+	assert.Equal(t, []string{"apple"}, actualResults)
+
+	assert.True(t, s.Remove(tasks["apple"]))
+	assert.False(t, s.Remove(tasks["apple"]))
+
+	currentTime = currentTime.Add(time.Second)
+	assert.NoError(t, s.Execute(time.Millisecond*100))
+	assert.Equal(t, []string{"apple"}, actualResults, "removed task should not run")
+}
+
+func Test_scheduler_ExecuteRemovedInSameCycle(t *testing.T) {
+	currentTime := testMakeTime(30, 0)
+	getTime := func() time.Time {
+		return currentTime
+	}
+
+	actualResults := make([]string, 0)
+	apple := testCreateTask(currentTime.Add(-(time.Second)), func(task *Task) {
+		task.priority = PriorityHigh
+	})
+	banana := testCreateTask(currentTime.Add(-(time.Second)))
+
+	s := NewScheduler(NewPrioritize(getTime))
+	s.Add(testPrepareTasksToRun(map[string]*Task{"apple": apple}, &actualResults, &currentTime)[0])
+	s.Add(testPrepareTasksToRun(map[string]*Task{"banana": banana}, &actualResults, &currentTime)[0])
+
+	// apple runs first, and remove banana, that already in run queue
+	appleFn := apple.taskFn
+	apple.taskFn = func() {
+		appleFn()
+		assert.True(t, s.Remove(banana))
+	}
+
+	assert.NoError(t, s.Execute(time.Millisecond*100))
+	assert.Equal(t, []string{"apple"}, actualResults, "removed task should not run")
+}
+
+func Test_scheduler_ExecuteStableOnTies(t *testing.T) {
+	names := []string{"apple", "banana", "orange", "cherry", "lemon"}
+
+	for i := 0; i < 50; i++ {
+		currentTime := testMakeTime(30, 0)
+		getTime := func() time.Time {
+			return currentTime
+		}
+
+		actualResults := make([]string, 0)
+		s := NewScheduler(NewPrioritize(getTime))
+
+		for _, name := range names {
+			// all tasks has equal priority and lastRunAt
+			task := testCreateTask(currentTime.Add(-(time.Second)))
+			s.Add(testPrepareTasksToRun(map[string]*Task{name: task}, &actualResults, &currentTime)[0])
+		}
+
+		// each task has 10ms duration, capacity cover only 3 of them
+		assert.NoError(t, s.Execute(time.Millisecond*35))
+		assert.Equal(t, []string{"apple", "banana", "orange"}, actualResults, "run #%d", i)
+	}
+}
+
+func Test_scheduler_ExecuteStableOnTiesByLastRun(t *testing.T) {
+	currentTime := testMakeTime(30, 0)
+	getTime := func() time.Time {
+		return currentTime
+	}
+
+	actualResults := make([]string, 0)
+	s := NewScheduler(NewPrioritize(getTime))
+
+	// registered first, but executed recently
+	recent := testCreateTask(currentTime.Add(-(time.Second)))
+
+	// executed longer ago, but waiting time relative
+	// to runAtLeastOnceIn is same, so priority is equal
+	older := testCreateTask(currentTime.Add(-(time.Second * 2)), func(task *Task) {
+		task.runAtLeastOnceIn = time.Second * 20
+	})
+
+	s.Add(testPrepareTasksToRun(map[string]*Task{"apple": recent}, &actualResults, &currentTime)[0])
+	s.Add(testPrepareTasksToRun(map[string]*Task{"banana": older}, &actualResults, &currentTime)[0])
+
+	// each task has 10ms duration, capacity cover only 1 of them
+	assert.NoError(t, s.Execute(time.Millisecond*15))
+	assert.Equal(t, recent.currentPriority, older.currentPriority, "tasks should have equal priority")
+	assert.Equal(t, []string{"banana"}, actualResults, "task with oldest lastRunAt should win")
+}
+
+func Test_scheduler_ExecuteRunOnce(t *testing.T) {
+	currentTime := testMakeTime(30, 0)
+	getTime := func() time.Time {
+		return currentTime
+	}
+
+	actualResults := make([]string, 0)
+	task := &Task{
+		priority:         PriorityNormal,
+		runAtLeastOnceIn: time.Second * 10,
+		runAtMostOnceIn:  time.Millisecond * 100,
+		runOnce:          true,
+	}
+
+	s := NewScheduler(NewPrioritize(getTime))
+	s.Add(testPrepareTasksToRun(map[string]*Task{"apple": task}, &actualResults, &currentTime)[0])
+
+	// just registered, but not have capacity for it
+	assert.NoError(t, s.Execute(0))
+	assert.Empty(t, actualResults)
+
+	// eligible right after registration, runAtMostOnceIn is not applied
+	currentTime = currentTime.Add(time.Millisecond * 10)
+	assert.NoError(t, s.Execute(time.Millisecond*100))
+	assert.Equal(t, []string{"apple"}, actualResults)
+	assert.False(t, s.Has(task), "one-shot task should be removed after run")
+
+	currentTime = currentTime.Add(time.Second * 20)
+	assert.NoError(t, s.Execute(time.Millisecond*100))
+	assert.Equal(t, []string{"apple"}, actualResults, "one-shot task should run only once")
+}
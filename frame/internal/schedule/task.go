@@ -4,9 +4,11 @@ import "time"
 
 type (
 	Task struct {
+		name             string
 		priority         Priority      // task schedule priority against another tasks
 		runAtLeastOnceIn time.Duration // but anyway it SHOULD be executed at least once per X time
 		runAtMostOnceIn  time.Duration // do not run it too often
+		runOnce          bool          // task will be removed from scheduler after first successful run
 		taskFn           taskFn
 
 		// stats
@@ -14,6 +16,18 @@ type (
 		lastRunAt       time.Time
 		avgDuration     time.Duration
 		runsCount       uint64
+		index           uint64 // registration order in scheduler
+		registeredAt    time.Time
+	}
+
+	TaskStats struct {
+		Name         string
+		RunsCount    uint64
+		AvgDuration  time.Duration
+		LastRunAt    time.Time
+		LastPriority float32
+
+		index uint64
 	}
 
 	taskFn = func()
@@ -24,11 +38,15 @@ func NewTask(
 	priority Priority,
 	runAtLeastOnceIn time.Duration,
 	runAtMostOnceIn time.Duration,
+	runOnce bool,
+	name string,
 ) *Task {
 	return &Task{
+		name:             name,
 		priority:         priority,
 		runAtLeastOnceIn: runAtLeastOnceIn,
 		runAtMostOnceIn:  runAtMostOnceIn,
+		runOnce:          runOnce,
 		taskFn:           fn,
 	}
 }
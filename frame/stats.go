@@ -27,6 +27,43 @@ type TickStats struct {
 	DeltaTime float64
 }
 
+type FrameStats struct {
+	// CycleID is number of game loop cycles since game start (this will auto inc to +1 every loop)
+	CycleID uint64
+
+	// Alpha is fraction of tick Rate, elapsed since last tick, in range [0..1]
+	// it can be used for interpolation between previous and current game state:
+	// 	renderX := prevX + (currentX-prevX)*Alpha
+	//
+	// This useful, when FPS > TPS, so frames between ticks will be smooth.
+	// Without WithTargetFPS frame always drawn right after tick, so Alpha is always 0
+	Alpha float64
+
+	// DeltaTime is time in seconds, elapsed since previous frame
+	DeltaTime float64
+}
+
+type TaskStats struct {
+	// Name of task, defined with WithName
+	Name string
+
+	// RunsCount is count of successful task executions
+	RunsCount uint64
+
+	// AvgDuration is average execution time of task
+	AvgDuration time.Duration
+
+	// LastRunAt is time of last task execution (zero, when task not executed yet)
+	LastRunAt time.Time
+
+	// LastPriority is task priority, calculated in last cycle:
+	//  -1 - task not need to run (runs too often)
+	//   0 - the lowest priority
+	//   1 - the highest priority
+	//   2 - task overdue, executed without capacity check
+	LastPriority float32
+}
+
 type Stats struct {
 	// CycleID is number of game loop cycles since game start (this will auto inc to +1 every loop)
 	CycleID uint64
@@ -35,6 +72,10 @@ type Stats struct {
 	// This is how many state updates game will have per second
 	TargetTPS int
 
+	// target frames per second (draw calls per second).
+	// Equal to TargetTPS, until frames not decoupled from ticks with WithTargetFPS
+	TargetFPS int
+
 	// maximum calculated FPS that can be theoretically achieved in current CPU
 	PossibleFPS int
 
@@ -50,4 +91,12 @@ type Stats struct {
 
 	CurrentTPS int // real counted ticks per second (ticks is fixed/physics update)
 	CurrentFPS int // real counted frames per second
+
+	// Paused is true, when executor is paused, ticks and tasks not executed in this cycle
+	Paused bool
+
+	// DroppedTicks is count of ticks, that not executed in current cycle,
+	// because cycle lagging too much and reach MaxTicksPerCycle limit.
+	// When greater than zero, game time is slower than real time
+	DroppedTicks int
 }
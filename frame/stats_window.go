@@ -0,0 +1,197 @@
+package frame
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const statsWindowMinCapacity = 64
+
+type Percentiles struct {
+	Min time.Duration
+	Avg time.Duration
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+type StatsSnapshot struct {
+	// Window is real covered time, from start of first to end of last collected cycle
+	// it can be less than configured window size right after game start
+	Window time.Duration
+
+	// Cycles is count of collected cycles in Window
+	Cycles int
+
+	Frame    Percentiles // frame durations (only cycles with drawn frame)
+	Tick     Percentiles // tick durations (only cycles with at least one tick)
+	Tasks    Percentiles // tasks durations of cycles
+	Throttle Percentiles // throttle (sleep) time of cycles
+
+	AvgTPS float64 // executed ticks per second over Window
+	AvgFPS float64 // drawn frames per second over Window
+}
+
+type (
+	statsWindow struct {
+		size time.Duration
+
+		// ring buffer of collected samples,
+		// will grow when all samples in buffer is still in window
+		samples []statsSample
+		head    int
+		count   int
+
+		// guard samples, snapshot can be
+		// requested from another goroutines
+		mux sync.Mutex
+	}
+
+	statsSample struct {
+		at       time.Time
+		end      time.Time
+		tick     time.Duration
+		frame    time.Duration
+		tasks    time.Duration
+		throttle time.Duration
+		ticks    int
+		hasFrame bool
+	}
+)
+
+func newStatsWindow(size time.Duration) *statsWindow {
+	return &statsWindow{
+		size:    size,
+		samples: make([]statsSample, statsWindowMinCapacity),
+	}
+}
+
+func (w *statsWindow) push(stats Stats, ticks int, hasFrame bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	end := stats.Cycle.Start.Add(stats.Cycle.Duration)
+
+	// drop outdated samples
+	for w.count > 0 && end.Sub(w.samples[w.head].at) > w.size {
+		w.head = (w.head + 1) % len(w.samples)
+		w.count--
+	}
+
+	if w.count == len(w.samples) {
+		w.grow()
+	}
+
+	w.samples[(w.head+w.count)%len(w.samples)] = statsSample{
+		at:       stats.Cycle.Start,
+		end:      end,
+		tick:     stats.Tick.Duration,
+		frame:    stats.Frame.Duration,
+		tasks:    stats.Tasks.Duration,
+		throttle: stats.ThrottleTime,
+		ticks:    ticks,
+		hasFrame: hasFrame,
+	}
+	w.count++
+}
+
+func (w *statsWindow) grow() {
+	grown := make([]statsSample, len(w.samples)*2)
+
+	for i := 0; i < w.count; i++ {
+		grown[i] = w.samples[(w.head+i)%len(w.samples)]
+	}
+
+	w.samples = grown
+	w.head = 0
+}
+
+func (w *statsWindow) snapshot() StatsSnapshot {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.count == 0 {
+		return StatsSnapshot{}
+	}
+
+	ticks := make([]time.Duration, 0, w.count)
+	frames := make([]time.Duration, 0, w.count)
+	tasks := make([]time.Duration, 0, w.count)
+	throttles := make([]time.Duration, 0, w.count)
+	sumTicks := 0
+	sumFrames := 0
+
+	for i := 0; i < w.count; i++ {
+		sample := w.samples[(w.head+i)%len(w.samples)]
+
+		if sample.ticks > 0 {
+			ticks = append(ticks, sample.tick)
+		}
+
+		if sample.hasFrame {
+			frames = append(frames, sample.frame)
+			sumFrames++
+		}
+
+		tasks = append(tasks, sample.tasks)
+		throttles = append(throttles, sample.throttle)
+		sumTicks += sample.ticks
+	}
+
+	first := w.samples[w.head]
+	last := w.samples[(w.head+w.count-1)%len(w.samples)]
+
+	snapshot := StatsSnapshot{
+		Window:   last.end.Sub(first.at),
+		Cycles:   w.count,
+		Frame:    calculatePercentiles(frames),
+		Tick:     calculatePercentiles(ticks),
+		Tasks:    calculatePercentiles(tasks),
+		Throttle: calculatePercentiles(throttles),
+	}
+
+	if snapshot.Window > 0 {
+		snapshot.AvgTPS = float64(sumTicks) / snapshot.Window.Seconds()
+		snapshot.AvgFPS = float64(sumFrames) / snapshot.Window.Seconds()
+	}
+
+	return snapshot
+}
+
+// calculatePercentiles will sort values in place,
+// and calculate percentiles with nearest-rank method
+func calculatePercentiles(values []time.Duration) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+
+	sum := time.Duration(0)
+	for _, value := range values {
+		sum += value
+	}
+
+	rank := func(p float64) time.Duration {
+		ind := int(math.Ceil(p*float64(len(values)))) - 1
+		if ind < 0 {
+			ind = 0
+		}
+
+		return values[ind]
+	}
+
+	return Percentiles{
+		Min: values[0],
+		Avg: sum / time.Duration(len(values)),
+		P50: rank(0.50),
+		P95: rank(0.95),
+		P99: rank(0.99),
+		Max: values[len(values)-1],
+	}
+}
@@ -0,0 +1,113 @@
+package frame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testMakeWindowStats(at time.Time, tick time.Duration, frame time.Duration) Stats {
+	return Stats{
+		Cycle:        Timings{Start: at, Duration: time.Millisecond * 100},
+		Tick:         Timings{Start: at, Duration: tick},
+		Frame:        Timings{Start: at.Add(tick), Duration: frame},
+		Tasks:        Timings{Start: at.Add(tick + frame), Duration: time.Millisecond * 2},
+		ThrottleTime: time.Millisecond*100 - tick - frame,
+	}
+}
+
+func Test_statsWindow_snapshot(t *testing.T) {
+	start := time.Date(2000, 01, 01, 12, 0, 0, 0, time.UTC)
+	window := newStatsWindow(time.Second)
+
+	// 200 cycles, will overflow initial buffer capacity
+	// and only last 1s (10 cycles) should be in window
+	for i := 0; i < 200; i++ {
+		window.push(
+			testMakeWindowStats(
+				start.Add(time.Duration(i)*time.Millisecond*100),
+				time.Duration(i)*time.Millisecond,
+				time.Millisecond*5,
+			),
+			1,
+			i%2 == 0,
+		)
+	}
+
+	snapshot := window.snapshot()
+
+	assert.Equal(t, time.Second, snapshot.Window)
+	assert.Equal(t, 10, snapshot.Cycles)
+
+	// ticks 190ms .. 199ms
+	assert.Equal(t, time.Millisecond*190, snapshot.Tick.Min)
+	assert.Equal(t, time.Microsecond*194500, snapshot.Tick.Avg)
+	assert.Equal(t, time.Millisecond*194, snapshot.Tick.P50)
+	assert.Equal(t, time.Millisecond*199, snapshot.Tick.P95)
+	assert.Equal(t, time.Millisecond*199, snapshot.Tick.P99)
+	assert.Equal(t, time.Millisecond*199, snapshot.Tick.Max)
+
+	// frames only in even cycles
+	assert.Equal(t, time.Millisecond*5, snapshot.Frame.Min)
+	assert.Equal(t, time.Millisecond*5, snapshot.Frame.Max)
+
+	assert.Equal(t, time.Millisecond*2, snapshot.Tasks.Min)
+	assert.Equal(t, time.Millisecond*2, snapshot.Tasks.Max)
+
+	// counted ticks and frames, not averaged CurrentTPS/FPS
+	assert.Equal(t, float64(10), snapshot.AvgTPS)
+	assert.Equal(t, float64(5), snapshot.AvgFPS)
+}
+
+func Test_statsWindow_snapshotEmpty(t *testing.T) {
+	assert.Equal(t, StatsSnapshot{}, newStatsWindow(time.Second).snapshot())
+	assert.Equal(t, StatsSnapshot{}, NewExecutor().StatsSnapshot())
+}
+
+func TestExecutor_StatsSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		tps     int
+		fps     int
+		wantTPS float64
+		wantFPS float64
+	}{
+		{name: "fps > tps", tps: 10, fps: 40, wantTPS: 10, wantFPS: 40},
+		{name: "fps < tps", tps: 40, fps: 10, wantTPS: 40, wantFPS: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			run := newTestCycles(clock, 60,
+				WithTargetTPS(tt.tps),
+				WithTargetFPS(tt.fps),
+				WithStatsWindow(time.Second),
+			)
+
+			err := run.execute(func(_ TickStats) error {
+				clock.Sleep(time.Millisecond * 20)
+				return nil
+			}, func(_ FrameStats) error {
+				clock.Sleep(time.Millisecond * 5)
+				return nil
+			})
+
+			snapshot := run.executor.StatsSnapshot()
+
+			// cycle rate is 25ms, so last 1s is 40 cycles
+			assert.NoError(t, err)
+			assert.Equal(t, time.Second, snapshot.Window)
+			assert.Equal(t, 40, snapshot.Cycles)
+			assert.Equal(t, tt.wantTPS, snapshot.AvgTPS)
+			assert.Equal(t, tt.wantFPS, snapshot.AvgFPS)
+
+			// cycles without tick/frame should not be counted in percentiles
+			assert.Equal(t, time.Millisecond*20, snapshot.Tick.Min)
+			assert.Equal(t, time.Millisecond*20, snapshot.Tick.Max)
+			assert.Equal(t, time.Millisecond*5, snapshot.Frame.Min)
+			assert.Equal(t, time.Millisecond*5, snapshot.Frame.Max)
+		})
+	}
+}
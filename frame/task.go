@@ -12,9 +12,11 @@ const (
 
 type Task struct {
 	fn               func()
+	name             string        // optional, used only for observability (see Executor.TaskStats)
 	priority         TaskPriority  // task schedule priority against another tasks
 	runAtLeastOnceIn time.Duration // but anyway it SHOULD be executed at least once per X time
 	runAtMostOnceIn  time.Duration // do not run it too often
+	runOnce          bool          // forget task after first successful run
 }
 
 func NewTask(fn func(), options ...TaskInitializer) *Task {
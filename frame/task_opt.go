@@ -18,6 +18,23 @@ func WithRunAtMostOnceIn(t time.Duration) TaskInitializer {
 	}
 }
 
+// WithRunOnce mark task as one-shot, it will be executed only once,
+// when cycle has free time, and then removed from executor.
+// One-shot task is eligible right after registration and waits for free time,
+// when it not executed in runAtLeastOnceIn, it will run without capacity check
+func WithRunOnce() TaskInitializer {
+	return func(task *Task) {
+		task.runOnce = true
+	}
+}
+
+// WithName set task name, that will be visible in Executor.TaskStats
+func WithName(name string) TaskInitializer {
+	return func(task *Task) {
+		task.name = name
+	}
+}
+
 func WithPriority(p TaskPriority) TaskInitializer {
 	return func(task *Task) {
 		task.priority = p
@@ -2,25 +2,27 @@ package frame
 
 import "github.com/go-glx/frames/frame/internal/schedule"
 
-func transformTasks(tasks []*Task) []*schedule.Task {
-	innerTasks := make([]*schedule.Task, 0, len(tasks))
-
-	for _, task := range tasks {
-		innerTasks = append(innerTasks, transformTaskToInternal(task))
-	}
-
-	return innerTasks
-}
-
 func transformTaskToInternal(task *Task) *schedule.Task {
 	return schedule.NewTask(
 		task.fn,
 		transformTaskPriorityToInternal(task.priority),
 		task.runAtLeastOnceIn,
 		task.runAtMostOnceIn,
+		task.runOnce,
+		task.name,
 	)
 }
 
+func transformTaskStatsFromInternal(stats schedule.TaskStats) TaskStats {
+	return TaskStats{
+		Name:         stats.Name,
+		RunsCount:    stats.RunsCount,
+		AvgDuration:  stats.AvgDuration,
+		LastRunAt:    stats.LastRunAt,
+		LastPriority: stats.LastPriority,
+	}
+}
+
 func transformTaskPriorityToInternal(p TaskPriority) schedule.Priority {
 	switch p {
 	case TaskPriorityLow: